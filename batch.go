@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestEntry is one line of a batch manifest: the same options a Job
+// carries, but with the CLI's default values (20 WPM, note E5, velocity 100,
+// no Program Change) applied by runBatch where the entry omits them, so a
+// manifest only needs to set what varies per entry
+// (e.g. just Text and WPM, to render all letters at several speeds).
+// Velocity and Program are pointers because 0 is a valid explicit value for
+// both (velocity 0 is a legal, if silent, note-on; program 0 is Acoustic
+// Grand Piano) and so can't be distinguished from "omitted" by its zero
+// value the way the other defaultable fields can.
+type manifestEntry struct {
+	Text       string `json:"text" yaml:"text"`
+	Output     string `json:"output" yaml:"output"`
+	Format     string `json:"format" yaml:"format"`
+	WPM        int    `json:"wpm" yaml:"wpm"`
+	Farnsworth int    `json:"farnsworth" yaml:"farnsworth"`
+	Note       string `json:"note" yaml:"note"`
+	Velocity   *int   `json:"velocity" yaml:"velocity"`
+	Channel    int    `json:"channel" yaml:"channel"`
+	Program    *int   `json:"program" yaml:"program"`
+	Chord      string `json:"chord" yaml:"chord"`
+	Strict     bool   `json:"strict" yaml:"strict"`
+	Multitrack bool   `json:"multitrack" yaml:"multitrack"`
+}
+
+// batchCommand renders every entry of a manifest file concurrently, using
+// Job.Render for each so batch mode shares its rendering logic with the
+// interactive CLI.
+var batchCommand = &cli.Command{
+	Name:      "batch",
+	Usage:     "render every entry of a YAML or JSON manifest concurrently",
+	ArgsUsage: "<manifest>",
+	Flags: []cli.Flag{
+		&cli.IntFlag{Name: "jobs", Value: runtime.NumCPU(), Usage: "number of entries to render concurrently"},
+	},
+	Action: func(ctx context.Context, c *cli.Command) error {
+		path := c.Args().First()
+		if path == "" {
+			return fmt.Errorf("usage: go-morse-midi batch <manifest>")
+		}
+		entries, err := loadManifest(path)
+		if err != nil {
+			return err
+		}
+		jobs := c.Int("jobs")
+		if jobs < 1 {
+			jobs = 1
+		}
+		return runBatch(ctx, entries, jobs)
+	},
+}
+
+// loadManifest reads a manifest file, choosing JSON or YAML decoding by the
+// file's extension (.json vs .yaml/.yml).
+func loadManifest(path string) ([]manifestEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+
+	var entries []manifestEntry
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &entries)
+	case ".json":
+		err = json.Unmarshal(data, &entries)
+	default:
+		return nil, fmt.Errorf("unknown manifest extension %q, expected .json, .yaml, or .yml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	return entries, nil
+}
+
+// runBatch renders entries through a pool of jobs workers, streaming a
+// progress line per entry to stderr as it finishes and returning an error
+// summarizing how many entries failed, if any.
+func runBatch(ctx context.Context, entries []manifestEntry, jobs int) error {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failures int
+
+	worker := func() {
+		defer wg.Done()
+		for i := range work {
+			e := entries[i]
+			velocity := 100
+			if e.Velocity != nil {
+				velocity = *e.Velocity
+			}
+			program := -1
+			if e.Program != nil {
+				program = *e.Program
+			}
+			job := Job{
+				Text:       e.Text,
+				Output:     e.Output,
+				Format:     e.Format,
+				WPM:        e.WPM,
+				Farnsworth: e.Farnsworth,
+				Strict:     e.Strict,
+				Note:       e.Note,
+				Velocity:   velocity,
+				Channel:    e.Channel,
+				Program:    program,
+				Chord:      e.Chord,
+				Multitrack: e.Multitrack,
+			}
+			if job.Note == "" {
+				job.Note = "E5"
+			}
+			if job.WPM == 0 {
+				job.WPM = 20
+			}
+
+			var log strings.Builder
+			err := job.Render(ctx, &log)
+
+			mu.Lock()
+			os.Stderr.WriteString(log.String())
+			if err != nil {
+				failures++
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s: FAILED: %v\n", i+1, len(entries), e.Text, err)
+			} else {
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s: OK\n", i+1, len(entries), e.Text)
+			}
+			mu.Unlock()
+		}
+	}
+
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go worker()
+	}
+	for i := range entries {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d entries failed", failures, len(entries))
+	}
+	return nil
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+)
+
+// decodeCommand inverts the pipeline, reconstructing text from a MIDI or
+// WAV file this tool (or a compatible one) produced.
+var decodeCommand = &cli.Command{
+	Name:      "decode",
+	Usage:     "decode a MIDI or WAV file back into text",
+	ArgsUsage: "<file>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{Name: "from", Value: "midi", Usage: "input format: midi or wav"},
+	},
+	Action: func(ctx context.Context, c *cli.Command) error {
+		path := c.Args().First()
+		if path == "" {
+			return fmt.Errorf("usage: go-morse-midi decode <file>")
+		}
+
+		var text string
+		var err error
+		switch from := c.String("from"); from {
+		case "midi":
+			text, err = decodeMIDI(path)
+		case "wav":
+			text, err = decodeWAV(path)
+		default:
+			return fmt.Errorf("unknown --from %q, expected midi or wav", from)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(text)
+		return nil
+	},
+}
+
+// cwEvent is one tone (a dot or dash) or silence (a gap) detected in a CW
+// signal, in whatever duration unit the caller uses (MIDI ticks for
+// decodeMIDI, seconds for decodeWAV).
+type cwEvent struct {
+	Mark     bool // true for a tone (dot/dash), false for a gap
+	Duration float64
+}
+
+// reverseMorseCode maps each Morse code string back to the rune it encodes,
+// built once from morseCode.
+var reverseMorseCode = func() map[string]rune {
+	m := make(map[string]rune, len(morseCode))
+	for r, code := range morseCode {
+		m[code] = r
+	}
+	return m
+}()
+
+// decodeEvents reconstructs text from a sequence of tone/gap events. It
+// auto-detects the dot unit as the shortest tone, classifies each tone as a
+// dot or dash and each gap as intra-character, inter-character, or
+// inter-word, and maps the resulting Morse codes back to runes via
+// reverseMorseCode. A code with no known mapping (e.g. a prosign, which this
+// tool doesn't attempt to reconstruct) is rendered as '?'.
+func decodeEvents(events []cwEvent) (string, error) {
+	dotUnit := math.Inf(1)
+	for _, e := range events {
+		if e.Mark && e.Duration < dotUnit {
+			dotUnit = e.Duration
+		}
+	}
+	if math.IsInf(dotUnit, 1) {
+		return "", fmt.Errorf("no tones found to decode")
+	}
+	wordGapThreshold := wordGapClusterThreshold(events, dotUnit)
+
+	var text strings.Builder
+	var code strings.Builder
+	flushChar := func() {
+		if code.Len() == 0 {
+			return
+		}
+		if r, ok := reverseMorseCode[code.String()]; ok {
+			text.WriteRune(r)
+		} else {
+			text.WriteRune('?')
+		}
+		code.Reset()
+	}
+
+	for _, e := range events {
+		ratio := e.Duration / dotUnit
+		if e.Mark {
+			if ratio < 2 {
+				code.WriteByte('.')
+			} else {
+				code.WriteByte('-')
+			}
+			continue
+		}
+		switch {
+		case ratio < 2: // intra-character gap: code continues
+		case e.Duration >= wordGapThreshold: // inter-word gap
+			flushChar()
+			text.WriteByte(' ')
+		default: // inter-character gap
+			flushChar()
+		}
+	}
+	flushChar()
+
+	return text.String(), nil
+}
+
+// wordGapClusterThreshold finds the duration above which a non-intra-
+// character gap should be classified as inter-word rather than
+// inter-character. Farnsworth timing (newTiming) can stretch CharGap and
+// WordGap to tens of dot units, well past any fixed multiple of dotUnit,
+// so rather than compare against a fixed ratio this clusters the actual
+// gap durations seen: sorted, the split sits at the biggest relative jump
+// between consecutive values, since a given file's char gaps and word
+// gaps are each rendered at one consistent duration. With fewer than two
+// distinct long-gap durations to split (e.g. a message with no inter-word
+// or no inter-character gaps at all), it falls back to 5.5 dots, the
+// midpoint of newTiming's unstretched 4-dot/7-dot spacing.
+func wordGapClusterThreshold(events []cwEvent, dotUnit float64) float64 {
+	var longGaps []float64
+	for _, e := range events {
+		if !e.Mark && e.Duration/dotUnit >= 2 {
+			longGaps = append(longGaps, e.Duration)
+		}
+	}
+	sort.Float64s(longGaps)
+
+	threshold := 5.5 * dotUnit
+	bestRatio := 1.0
+	for i := 1; i < len(longGaps); i++ {
+		if longGaps[i-1] <= 0 {
+			continue
+		}
+		if ratio := longGaps[i] / longGaps[i-1]; ratio > bestRatio {
+			bestRatio = ratio
+			threshold = math.Sqrt(longGaps[i] * longGaps[i-1])
+		}
+	}
+	return threshold
+}
+
+// noteSpan is one NoteOn-to-NoteOff span, in absolute MIDI ticks.
+type noteSpan struct {
+	on, off int
+}
+
+// decodeMIDI reads a Standard MIDI File written by this tool (or any
+// monophonic MIDI file on a single channel) and reconstructs the text it
+// encodes, by walking delta-times with readVarLength, collecting each
+// NoteOn-to-NoteOff span, and classifying the spans and the rests between
+// them against an auto-detected dot unit.
+func decodeMIDI(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", filename, err)
+	}
+	if len(data) < 14 || string(data[0:4]) != "MThd" {
+		return "", fmt.Errorf("%s is not a Standard MIDI File", filename)
+	}
+
+	if len(data) < 8 {
+		return "", fmt.Errorf("%s: truncated MThd chunk", filename)
+	}
+	pos := 8 + int(binary.BigEndian.Uint32(data[4:8]))
+
+	var spans []noteSpan
+	for pos+8 <= len(data) && string(data[pos:pos+4]) == "MTrk" {
+		trackLen := int(binary.BigEndian.Uint32(data[pos+4 : pos+8]))
+		if trackLen < 0 || pos+8+trackLen > len(data) {
+			return "", fmt.Errorf("%s: truncated MTrk chunk", filename)
+		}
+		track := data[pos+8 : pos+8+trackLen]
+		pos += 8 + trackLen
+
+		tick := 0
+		onTick := -1
+		var status byte
+		i := 0
+		for i < len(track) {
+			delta, next, err := readVarLength(track, i)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", filename, err)
+			}
+			i = next
+			tick += delta
+
+			if i >= len(track) {
+				return "", fmt.Errorf("%s: truncated MIDI event", filename)
+			}
+			if track[i] >= 0x80 {
+				status = track[i]
+				i++
+			}
+
+			switch status & 0xF0 {
+			case 0x90:
+				if i+1 >= len(track) {
+					return "", fmt.Errorf("%s: truncated NoteOn event", filename)
+				}
+				velocity := track[i+1]
+				i += 2
+				if velocity > 0 {
+					if onTick < 0 {
+						onTick = tick
+					}
+				} else if onTick >= 0 {
+					spans = append(spans, noteSpan{on: onTick, off: tick})
+					onTick = -1
+				}
+			case 0x80:
+				if i+1 >= len(track) {
+					return "", fmt.Errorf("%s: truncated NoteOff event", filename)
+				}
+				i += 2
+				if onTick >= 0 {
+					spans = append(spans, noteSpan{on: onTick, off: tick})
+					onTick = -1
+				}
+			case 0xC0, 0xD0:
+				if i >= len(track) {
+					return "", fmt.Errorf("%s: truncated MIDI event", filename)
+				}
+				i++
+			case 0xF0:
+				if status == 0xFF {
+					if i >= len(track) {
+						return "", fmt.Errorf("%s: truncated meta event", filename)
+					}
+					i++ // meta type
+				}
+				length, next2, err := readVarLength(track, i)
+				if err != nil {
+					return "", fmt.Errorf("%s: %w", filename, err)
+				}
+				if next2+length > len(track) {
+					return "", fmt.Errorf("%s: truncated meta/sysex event", filename)
+				}
+				i = next2 + length
+			default:
+				if i+1 >= len(track) {
+					return "", fmt.Errorf("%s: truncated MIDI event", filename)
+				}
+				i += 2
+			}
+		}
+	}
+
+	return decodeEvents(spansToEvents(spans))
+}
+
+// spansToEvents turns consecutive note spans into a tone/gap event sequence:
+// each span's own duration, preceded by the rest since the previous span
+// (omitted before the first span, since there is nothing before it).
+func spansToEvents(spans []noteSpan) []cwEvent {
+	var events []cwEvent
+	for i, s := range spans {
+		if i > 0 {
+			events = append(events, cwEvent{Mark: false, Duration: float64(s.on - spans[i-1].off)})
+		}
+		events = append(events, cwEvent{Mark: true, Duration: float64(s.off - s.on)})
+	}
+	return events
+}
+
+// readVarLength reads a MIDI variable-length quantity from data starting at
+// offset, returning the decoded value and the offset just past it. It is
+// the inverse of writeVarLength. It returns an error instead of panicking
+// if the quantity runs past the end of data, which a truncated or corrupt
+// file can trigger.
+func readVarLength(data []byte, offset int) (int, int, error) {
+	value := 0
+	for {
+		if offset >= len(data) {
+			return 0, 0, fmt.Errorf("truncated variable-length quantity")
+		}
+		b := data[offset]
+		offset++
+		value = (value << 7) | int(b&0x7F)
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return value, offset, nil
+}
+
+// envelopeWindowSeconds is the RMS window size used by decodeWAV, chosen to
+// be much shorter than even a fast dot while still smoothing over a
+// sample's worth of the sidetone's own oscillation.
+const envelopeWindowSeconds = 0.010
+
+// decodeWAV reads a mono 16-bit PCM WAV file (such as one written by
+// createWAV) and reconstructs the text it encodes, using an RMS envelope
+// over envelopeWindowSeconds windows, thresholded at half the envelope's
+// peak, to find on/off spans, then classifying them the same way decodeMIDI
+// does.
+func decodeWAV(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", filename, err)
+	}
+	sampleRate, samples, err := parseWAV(data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", filename, err)
+	}
+
+	windowSize := int(envelopeWindowSeconds * float64(sampleRate))
+	if windowSize < 1 {
+		windowSize = 1
+	}
+	nSamples := len(samples) / 2
+
+	var rms []float64
+	for start := 0; start < nSamples; start += windowSize {
+		end := start + windowSize
+		if end > nSamples {
+			end = nSamples
+		}
+		var sumSq float64
+		for i := start; i < end; i++ {
+			v := int16(binary.LittleEndian.Uint16(samples[i*2 : i*2+2]))
+			sumSq += float64(v) * float64(v)
+		}
+		rms = append(rms, math.Sqrt(sumSq/float64(end-start)))
+	}
+
+	maxRMS := 0.0
+	for _, r := range rms {
+		if r > maxRMS {
+			maxRMS = r
+		}
+	}
+	if maxRMS == 0 {
+		return "", fmt.Errorf("no audio signal found to decode")
+	}
+	threshold := maxRMS / 2
+	windowSeconds := float64(windowSize) / float64(sampleRate)
+
+	var events []cwEvent
+	on := rms[0] >= threshold
+	spanStart := 0
+	for i := 1; i < len(rms); i++ {
+		isOn := rms[i] >= threshold
+		if isOn != on {
+			events = append(events, cwEvent{Mark: on, Duration: float64(i-spanStart) * windowSeconds})
+			on = isOn
+			spanStart = i
+		}
+	}
+	events = append(events, cwEvent{Mark: on, Duration: float64(len(rms)-spanStart) * windowSeconds})
+
+	for len(events) > 0 && !events[0].Mark {
+		events = events[1:]
+	}
+	for len(events) > 0 && !events[len(events)-1].Mark {
+		events = events[:len(events)-1]
+	}
+
+	return decodeEvents(events)
+}
+
+// parseWAV reads a mono 16-bit PCM RIFF/WAVE file, the inverse of wavFile,
+// and returns its sample rate and raw little-endian sample bytes.
+func parseWAV(data []byte) (int, []byte, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return 0, nil, fmt.Errorf("not a RIFF/WAVE file")
+	}
+
+	pos := 12
+	var sampleRate int
+	var samples []byte
+	for pos+8 <= len(data) {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		if size < 0 || pos+8+size > len(data) {
+			return 0, nil, fmt.Errorf("chunk %q size %d exceeds file length", id, size)
+		}
+		body := data[pos+8 : pos+8+size]
+		switch id {
+		case "fmt ":
+			if len(body) < 8 {
+				return 0, nil, fmt.Errorf("truncated fmt chunk")
+			}
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+		case "data":
+			samples = body
+		}
+		pos += 8 + size
+	}
+	if samples == nil {
+		return 0, nil, fmt.Errorf("no data chunk found")
+	}
+	return sampleRate, samples, nil
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// defaultMIDIOpts mirrors the CLI defaults (--note E5 --velocity 100
+// --channel 0 --program -1) used by the round-trip tests below.
+var defaultMIDIOpts = midiOptions{Notes: []int{76}, Velocity: 100, Channel: 0, Program: -1}
+
+func TestDecodeMIDIRoundTrip(t *testing.T) {
+	morse, err := textToMorse("SOS", false)
+	if err != nil {
+		t.Fatalf("textToMorse: %v", err)
+	}
+	timing, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sos.mid")
+	if err := createMIDI(morse, path, defaultMIDIOpts, timing); err != nil {
+		t.Fatalf("createMIDI: %v", err)
+	}
+
+	text, err := decodeMIDI(path)
+	if err != nil {
+		t.Fatalf("decodeMIDI: %v", err)
+	}
+	if text != "sos" {
+		t.Errorf("decodeMIDI round trip = %q, want %q", text, "sos")
+	}
+}
+
+func TestDecodeMIDIFarnsworthRoundTrip(t *testing.T) {
+	morse, err := textToMorse("hello world", false)
+	if err != nil {
+		t.Fatalf("textToMorse: %v", err)
+	}
+	timing, err := newTiming(20, 5)
+	if err != nil {
+		t.Fatalf("newTiming: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "hello-world.mid")
+	if err := createMIDI(morse, path, defaultMIDIOpts, timing); err != nil {
+		t.Fatalf("createMIDI: %v", err)
+	}
+
+	text, err := decodeMIDI(path)
+	if err != nil {
+		t.Fatalf("decodeMIDI: %v", err)
+	}
+	if text != "hello world" {
+		t.Errorf("decodeMIDI farnsworth round trip = %q, want %q", text, "hello world")
+	}
+}
+
+func TestDecodeMIDITruncatedFile(t *testing.T) {
+	morse, err := textToMorse("SOS", false)
+	if err != nil {
+		t.Fatalf("textToMorse: %v", err)
+	}
+	timing, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sos.mid")
+	if err := createMIDI(morse, path, defaultMIDIOpts, timing); err != nil {
+		t.Fatalf("createMIDI: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	truncated := filepath.Join(t.TempDir(), "truncated.mid")
+	if err := os.WriteFile(truncated, data[:len(data)-5], 0644); err != nil {
+		t.Fatalf("writing %s: %v", truncated, err)
+	}
+
+	if _, err := decodeMIDI(truncated); err == nil {
+		t.Error("decodeMIDI(truncated file) = nil error, want an error")
+	}
+}
+
+func TestDecodeWAVRoundTrip(t *testing.T) {
+	morse, err := textToMorse("SOS", false)
+	if err != nil {
+		t.Fatalf("textToMorse: %v", err)
+	}
+	timing, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sos.wav")
+	if err := createWAV(morse, path, timing); err != nil {
+		t.Fatalf("createWAV: %v", err)
+	}
+
+	text, err := decodeWAV(path)
+	if err != nil {
+		t.Fatalf("decodeWAV: %v", err)
+	}
+	if text != "sos" {
+		t.Errorf("decodeWAV round trip = %q, want %q", text, "sos")
+	}
+}
+
+func TestParseWAVOversizedChunk(t *testing.T) {
+	morse, err := textToMorse("SOS", false)
+	if err != nil {
+		t.Fatalf("textToMorse: %v", err)
+	}
+	timing, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "sos.wav")
+	if err := createWAV(morse, path, timing); err != nil {
+		t.Fatalf("createWAV: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+
+	// Corrupt the data chunk's size field (bytes 40-43) to claim far more
+	// bytes than the file actually has.
+	data[40], data[41], data[42], data[43] = 0xFF, 0xFF, 0xFF, 0x7F
+
+	if _, _, err := parseWAV(data); err == nil {
+		t.Error("parseWAV(oversized chunk) = nil error, want an error")
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Job is a single fully-resolved rendering request: the text to convert and
+// every option that affects its output. It is the shared unit of work
+// between the interactive CLI (one Job built from flags) and batch mode
+// (one Job per manifest entry), so both paths render through the same
+// Render method.
+type Job struct {
+	Text       string
+	Output     string // if empty, derived from Text and Format
+	Format     string // "midi" or "wav"
+	WPM        int
+	Farnsworth int
+	Strict     bool
+	Note       string
+	Velocity   int
+	Channel    int
+	Program    int
+	Chord      string
+	Multitrack bool
+}
+
+// Render converts j.Text to Morse code and writes it to j.Output (or a name
+// derived from j.Text if empty) in j.Format, logging progress and the final
+// file name to log. It is the single code path used by both the
+// interactive CLI and batch mode.
+func (j Job) Render(ctx context.Context, log io.Writer) error {
+	if strings.TrimSpace(j.Text) == "" {
+		return fmt.Errorf("no text provided, please provide text to convert to Morse code")
+	}
+	fmt.Fprintln(log, "Input text:", j.Text)
+
+	morse, err := textToMorse(j.Text, j.Strict)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(log, "Morse code:", morse)
+
+	timing, err := newTiming(j.WPM, j.Farnsworth)
+	if err != nil {
+		return err
+	}
+
+	baseNote, err := parseNote(j.Note)
+	if err != nil {
+		return err
+	}
+	chordNotes, err := parseChord(j.Chord, baseNote)
+	if err != nil {
+		return err
+	}
+	midiOpts := midiOptions{
+		Notes:    chordNotes,
+		Velocity: j.Velocity,
+		Channel:  j.Channel,
+		Program:  j.Program,
+	}
+	if err := validateMIDIOptions(midiOpts); err != nil {
+		return err
+	}
+
+	format := j.Format
+	if format == "" {
+		format = "midi"
+	}
+
+	outputFile := j.Output
+	switch format {
+	case "midi":
+		if outputFile == "" {
+			outputFile = strings.ReplaceAll(j.Text, " ", "-") + ".mid"
+		}
+		if j.Multitrack {
+			chars, err := tokenizeMorse(j.Text, j.Strict)
+			if err != nil {
+				return err
+			}
+			err = createMultiTrackMIDI(chars, morse, outputFile, midiOpts, timing)
+			if err != nil {
+				return err
+			}
+		} else if err := createMIDI(morse, outputFile, midiOpts, timing); err != nil {
+			return err
+		}
+	case "wav":
+		if outputFile == "" {
+			outputFile = strings.ReplaceAll(j.Text, " ", "-") + ".wav"
+		}
+		if err := createWAV(morse, outputFile, timing); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown format %q, expected midi or wav", format)
+	}
+
+	fmt.Fprintf(log, "Output saved as %s\n", outputFile)
+	return nil
+}
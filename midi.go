@@ -0,0 +1,152 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// Helper function to write a MIDI variable-length quantity
+func writeVarLength(value int) []byte {
+	buf := []byte{}
+	buffer := value & 0x7F
+	for value >>= 7; value > 0; value >>= 7 {
+		buffer <<= 8
+		buffer |= ((value & 0x7F) | 0x80)
+	}
+	for {
+		buf = append(buf, byte(buffer))
+		if buffer&0x80 != 0 {
+			buffer >>= 8
+		} else {
+			break
+		}
+	}
+	return buf
+}
+
+const microsecondsPerMinute = 60000000
+
+// midiOptions configures the voicing used when rendering a MIDI file: the
+// pitch(es) played for each dot/dash (more than one note makes a chord),
+// the velocity and channel of those notes, and an optional Program Change
+// sent at t=0 to select a General MIDI instrument.
+type midiOptions struct {
+	Notes    []int
+	Velocity int
+	Channel  int
+	Program  int // negative means no Program Change event is emitted
+}
+
+// validateMIDIOptions rejects velocity, channel, and program values outside
+// what a MIDI data byte (or channel nibble) can represent, instead of
+// letting them wrap silently when packed into status/data bytes (e.g. a
+// channel above 15 flipping a NoteOn into a different message type).
+func validateMIDIOptions(opts midiOptions) error {
+	if opts.Velocity < 0 || opts.Velocity > 127 {
+		return fmt.Errorf("velocity %d out of MIDI range 0-127", opts.Velocity)
+	}
+	if opts.Channel < 0 || opts.Channel > 15 {
+		return fmt.Errorf("channel %d out of MIDI range 0-15", opts.Channel)
+	}
+	if opts.Program < -1 || opts.Program > 127 {
+		return fmt.Errorf("program %d out of MIDI range 0-127 (-1 for none)", opts.Program)
+	}
+	return nil
+}
+
+// buildNoteTrack renders the Morse note events for morse using opts (an
+// optional Program Change followed by a NoteOn/NoteOff pair, or a chord of
+// them, per dot/dash) at the given timing, ending with an End of Track
+// event. It does not include a tempo event, so callers combine it with a
+// conductor track (Format 1, see createMultiTrackMIDI) or prepend their own
+// tempo event (Format 0, see createMIDI).
+func buildNoteTrack(morse string, opts midiOptions, timing noteTiming) []byte {
+	var track []byte
+
+	if opts.Program >= 0 {
+		track = append(track, 0x00, 0xC0|byte(opts.Channel), byte(opts.Program))
+	}
+
+	time := 0
+
+	addNote := func(duration int) {
+		track = append(track, writeVarLength(time)...)
+		for i, note := range opts.Notes {
+			if i > 0 {
+				track = append(track, 0x00)
+			}
+			track = append(track, 0x90|byte(opts.Channel), byte(note), byte(opts.Velocity))
+		}
+		for i, note := range opts.Notes {
+			if i == 0 {
+				track = append(track, writeVarLength(duration)...)
+			} else {
+				track = append(track, 0x00)
+			}
+			track = append(track, 0x80|byte(opts.Channel), byte(note), 0x00)
+		}
+	}
+
+	for _, symbol := range morse {
+		switch symbol {
+		case '.':
+			addNote(timing.Dot)
+			time = timing.Dot
+		case '-':
+			addNote(timing.Dash)
+			time = timing.Dot
+		case ' ':
+			time = timing.CharGap
+		case '/':
+			time = timing.WordGap
+		}
+	}
+
+	track = append(track, writeVarLength(time)...)
+	track = append(track, 0xFF, 0x2F, 0x00)
+	return track
+}
+
+// tempoEvent returns a t=0 Set Tempo meta event for the given timing.
+func tempoEvent(timing noteTiming) []byte {
+	microsecondsPerQuarterNote := timing.MicrosecondsPerQuarterNote
+	return []byte{
+		0x00, 0xFF, 0x51, 0x03,
+		byte((microsecondsPerQuarterNote >> 16) & 0xFF),
+		byte((microsecondsPerQuarterNote >> 8) & 0xFF),
+		byte(microsecondsPerQuarterNote & 0xFF),
+	}
+}
+
+func createMIDI(morse string, filename string, opts midiOptions, timing noteTiming) error {
+	track := append(tempoEvent(timing), buildNoteTrack(morse, opts, timing)...)
+	return writeSMF(filename, 0x0000, timing.TicksPerBeat, [][]byte{track})
+}
+
+// writeSMF writes a Standard MIDI File with the given format (0 for a
+// single track, 1 for independent simultaneous tracks) and one MTrk chunk
+// per entry in tracks, each already ending with its own End of Track event.
+func writeSMF(filename string, format uint16, division int, tracks [][]byte) error {
+	header := []byte{'M', 'T', 'h', 'd', 0x00, 0x00, 0x00, 0x06}
+	formatBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(formatBytes, format)
+	header = append(header, formatBytes...)
+	ntrksBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(ntrksBytes, uint16(len(tracks)))
+	header = append(header, ntrksBytes...)
+	divisionBytes := make([]byte, 2)
+	binary.BigEndian.PutUint16(divisionBytes, uint16(division))
+	header = append(header, divisionBytes...)
+
+	data := header
+	for _, track := range tracks {
+		data = append(data, 'M', 'T', 'r', 'k')
+		length := make([]byte, 4)
+		binary.BigEndian.PutUint32(length, uint32(len(track)))
+		data = append(data, length...)
+		data = append(data, track...)
+	}
+
+	return os.WriteFile(filename, data, 0644)
+}
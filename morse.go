@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// morseCode maps each supported letter, digit, and punctuation rune to its
+// International Morse Code (ITU-R M.1677-1) representation.
+var morseCode = map[rune]string{
+	'a': ".-", 'b': "-...", 'c': "-.-.", 'd': "-..", 'e': ".",
+	'f': "..-.", 'g': "--.", 'h': "....", 'i': "..", 'j': ".---",
+	'k': "-.-", 'l': ".-..", 'm': "--", 'n': "-.", 'o': "---",
+	'p': ".--.", 'q': "--.-", 'r': ".-.", 's': "...", 't': "-",
+	'u': "..-", 'v': "...-", 'w': ".--", 'x': "-..-", 'y': "-.--",
+	'z': "--..",
+
+	'0': "-----", '1': ".----", '2': "..---", '3': "...--", '4': "....-",
+	'5': ".....", '6': "-....", '7': "--...", '8': "---..", '9': "----.",
+
+	'.': ".-.-.-", ',': "--..--", '?': "..--..", '\'': ".----.",
+	'!': "-.-.--", '/': "-..-.", '(': "-.--.", ')': "-.--.-",
+	'&': ".-...", ':': "---...", ';': "-.-.-.", '=': "-...-",
+	'+': ".-.-.", '-': "-....-", '_': "..--.-", '"': ".-..-.",
+	'$': "...-..-", '@': ".--.-.",
+}
+
+// prosignLetters maps a prosign token, as written inside angle brackets
+// (e.g. "<SOS>"), to the plain letters it is keyed from.
+var prosignLetters = map[string]string{
+	"SOS": "sos",
+	"AR":  "ar",
+	"SK":  "sk",
+	"BT":  "bt",
+	"KN":  "kn",
+}
+
+// prosignCode returns the Morse representation of a prosign: each letter's
+// code run together with no intra-character space.
+func prosignCode(name string) (string, bool) {
+	letters, ok := prosignLetters[strings.ToUpper(name)]
+	if !ok {
+		return "", false
+	}
+	var code strings.Builder
+	for _, letter := range letters {
+		code.WriteString(morseCode[letter])
+	}
+	return code.String(), true
+}
+
+// morseChar is one character (letter, digit, punctuation rune, or prosign)
+// of Morse-coded text, keeping the label it came from and whether it starts
+// a new word. It is the structured form that textToMorse flattens into a
+// single string, and the basis for the translation track written by
+// createMultiTrackMIDI.
+type morseChar struct {
+	Label     string // the original letter/digit/punctuation or prosign name
+	Code      string // its Morse code, e.g. ".-"
+	WordBreak bool   // true if this character starts a new word
+}
+
+// tokenizeMorse walks text the same way textToMorse does, but keeps each
+// character's code, label, and word boundary instead of flattening them
+// into a single string.
+func tokenizeMorse(text string, strict bool) ([]morseChar, error) {
+	var chars []morseChar
+	words := strings.Split(strings.ToLower(text), " ")
+	for wi, word := range words {
+		first := true
+		for i := 0; i < len(word); {
+			if word[i] == '<' {
+				if end := strings.IndexByte(word[i:], '>'); end > 0 {
+					token := word[i+1 : i+end]
+					if code, ok := prosignCode(token); ok {
+						chars = append(chars, morseChar{
+							Label:     strings.ToUpper(token),
+							Code:      code,
+							WordBreak: wi > 0 && first,
+						})
+						first = false
+						i += end + 1
+						continue
+					}
+					if strict {
+						return nil, fmt.Errorf("unknown prosign <%s>", token)
+					}
+					i += end + 1
+					continue
+				}
+			}
+			char, size := utf8.DecodeRuneInString(word[i:])
+			code, ok := morseCode[char]
+			if !ok {
+				if strict {
+					return nil, fmt.Errorf("unsupported character %q", char)
+				}
+				i += size
+				continue
+			}
+			chars = append(chars, morseChar{
+				Label:     string(char),
+				Code:      code,
+				WordBreak: wi > 0 && first,
+			})
+			first = false
+			i += size
+		}
+	}
+	return chars, nil
+}
+
+// textToMorse converts text to Morse code, one "/"-separated group per word
+// and one space-separated symbol per letter. A token written as
+// "<NAME>" (e.g. "<SOS>") is looked up as a prosign instead of individual
+// letters. When strict is true, an unsupported rune or unknown prosign is
+// reported as an error instead of being silently skipped.
+func textToMorse(text string, strict bool) (string, error) {
+	chars, err := tokenizeMorse(text, strict)
+	if err != nil {
+		return "", err
+	}
+	var words [][]string
+	for _, c := range chars {
+		if len(words) == 0 || c.WordBreak {
+			words = append(words, nil)
+		}
+		words[len(words)-1] = append(words[len(words)-1], c.Code)
+	}
+	wordStrs := make([]string, len(words))
+	for i, w := range words {
+		wordStrs[i] = strings.Join(w, " ")
+	}
+	return strings.Join(wordStrs, "/"), nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import "testing"
+
+func TestTextToMorseDigits(t *testing.T) {
+	morse, err := textToMorse("123", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ".---- ..--- ...--"
+	if morse != want {
+		t.Errorf("textToMorse(%q) = %q, want %q", "123", morse, want)
+	}
+}
+
+func TestTextToMorsePunctuation(t *testing.T) {
+	morse, err := textToMorse(".,?", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ".-.-.- --..-- ..--.."
+	if morse != want {
+		t.Errorf("textToMorse(%q) = %q, want %q", ".,?", morse, want)
+	}
+}
+
+func TestTextToMorseProsign(t *testing.T) {
+	morse, err := textToMorse("<SOS>", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "...---..."
+	if morse != want {
+		t.Errorf("textToMorse(%q) = %q, want %q", "<SOS>", morse, want)
+	}
+}
+
+func TestTextToMorseUnknownProsignNonStrict(t *testing.T) {
+	morse, err := textToMorse("<XYZ>", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if morse != "" {
+		t.Errorf("textToMorse(%q) = %q, want empty", "<XYZ>", morse)
+	}
+}
+
+func TestTextToMorseStrictUnsupportedChar(t *testing.T) {
+	if _, err := textToMorse("hi~", true); err == nil {
+		t.Error("expected error for unsupported character in strict mode, got nil")
+	}
+}
+
+func TestTextToMorseStrictUnknownProsign(t *testing.T) {
+	if _, err := textToMorse("<XYZ>", true); err == nil {
+		t.Error("expected error for unknown prosign in strict mode, got nil")
+	}
+}
+
+func TestTextToMorseStrictMultiByteRune(t *testing.T) {
+	_, err := textToMorse("café", true)
+	if err == nil {
+		t.Fatal("expected error for unsupported character in strict mode, got nil")
+	}
+	want := `unsupported character 'é'`
+	if err.Error() != want {
+		t.Errorf("textToMorse(%q, true) error = %q, want %q", "café", err.Error(), want)
+	}
+}
+
+func TestTextToMorseNonStrictSkipsUnsupportedChar(t *testing.T) {
+	morse, err := textToMorse("a~b", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := ".- -..."
+	if morse != want {
+		t.Errorf("textToMorse(%q) = %q, want %q", "a~b", morse, want)
+	}
+}
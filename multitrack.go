@@ -0,0 +1,97 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// buildConductorTrack renders a Format 1 conductor track: just the tempo
+// and time signature (4/4) meta events plus a track name, with no notes.
+func buildConductorTrack(timing noteTiming) []byte {
+	track := tempoEvent(timing)
+	track = append(track, 0x00, 0xFF, 0x58, 0x04, 0x04, 0x02, 0x18, 0x08)
+	track = append(track, 0x00, 0xFF, 0x03, byte(len("Conductor")))
+	track = append(track, []byte("Conductor")...)
+	track = append(track, 0x00, 0xFF, 0x2F, 0x00)
+	return track
+}
+
+// buildTranslationTrack emits a Text (0xFF 0x01) meta event at the tick
+// where each word begins and a Lyric (0xFF 0x05) meta event at the tick
+// where each character begins, using the same timing as buildNoteTrack, so
+// DAWs and karaoke players can show the decoded text in sync with the note
+// track.
+func buildTranslationTrack(chars []morseChar, timing noteTiming) []byte {
+	// First pass: the tick at which each character's first note fires,
+	// following exactly the same time-accumulation buildNoteTrack uses.
+	ticks := make([]int, len(chars))
+	pos, gap := 0, 0
+	for i, c := range chars {
+		ticks[i] = pos + gap
+		for _, symbol := range c.Code {
+			pos += gap
+			if symbol == '.' {
+				pos += timing.Dot
+			} else {
+				pos += timing.Dash
+			}
+			gap = timing.Dot
+		}
+		if i+1 < len(chars) {
+			if chars[i+1].WordBreak {
+				gap = timing.WordGap
+			} else {
+				gap = timing.CharGap
+			}
+		}
+	}
+
+	type marker struct {
+		tick int
+		meta byte
+		text string
+	}
+	var markers []marker
+	var word strings.Builder
+	wordStart := 0
+	flushWord := func() {
+		if word.Len() > 0 {
+			markers = append(markers, marker{tick: wordStart, meta: 0x01, text: word.String()})
+			word.Reset()
+		}
+	}
+	for i, c := range chars {
+		if i == 0 || c.WordBreak {
+			flushWord()
+			wordStart = ticks[i]
+		}
+		word.WriteString(c.Label)
+		markers = append(markers, marker{tick: ticks[i], meta: 0x05, text: c.Label})
+	}
+	flushWord()
+
+	sort.SliceStable(markers, func(i, j int) bool { return markers[i].tick < markers[j].tick })
+
+	var track []byte
+	lastTick := 0
+	for _, m := range markers {
+		track = append(track, writeVarLength(m.tick-lastTick)...)
+		track = append(track, 0xFF, m.meta, byte(len(m.text)))
+		track = append(track, []byte(m.text)...)
+		lastTick = m.tick
+	}
+	track = append(track, writeVarLength(0)...)
+	track = append(track, 0xFF, 0x2F, 0x00)
+	return track
+}
+
+// createMultiTrackMIDI renders morse as a Format 1 Standard MIDI File with
+// three independent tracks: a conductor track (tempo/time signature/track
+// name), the Morse note track built from opts, and a translation track
+// carrying the decoded text as Text/Lyric meta events in sync with it.
+func createMultiTrackMIDI(chars []morseChar, morse string, filename string, opts midiOptions, timing noteTiming) error {
+	conductor := buildConductorTrack(timing)
+	notes := buildNoteTrack(morse, opts, timing)
+	translation := buildTranslationTrack(chars, timing)
+	return writeSMF(filename, 0x0001, timing.TicksPerBeat, [][]byte{conductor, notes, translation})
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// noteLetterSemitone maps a natural note letter to its semitone offset from C.
+var noteLetterSemitone = map[byte]int{
+	'c': 0, 'd': 2, 'e': 4, 'f': 5, 'g': 7, 'a': 9, 'b': 11,
+}
+
+// parseNote resolves a --note flag value to a MIDI key number. It accepts
+// either a bare MIDI number (e.g. "76") or a note name in scientific pitch
+// notation (e.g. "E5", "C#4", "Bb3"), using the usual MIDI convention where
+// C-1 is key 0, so middle C (C4) is key 60 and freq = 440 * 2^((n-69)/12).
+func parseNote(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return validateNote(n)
+	}
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid note %q", s)
+	}
+	semitone, ok := noteLetterSemitone[strings.ToLower(s)[0]]
+	if !ok {
+		return 0, fmt.Errorf("invalid note %q", s)
+	}
+	rest := s[1:]
+	switch {
+	case strings.HasPrefix(rest, "#"):
+		semitone++
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "b"):
+		semitone--
+		rest = rest[1:]
+	}
+	octave, err := strconv.Atoi(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid note %q", s)
+	}
+	return validateNote((octave+1)*12 + semitone)
+}
+
+// validateNote rejects a resolved MIDI key number outside the 0-127 range
+// that a MIDI data byte can hold, instead of letting it wrap when packed
+// into a NoteOn/NoteOff event.
+func validateNote(n int) (int, error) {
+	if n < 0 || n > 127 {
+		return 0, fmt.Errorf("note %d out of MIDI range 0-127", n)
+	}
+	return n, nil
+}
+
+// parseChord parses a comma-separated list of semitone intervals (e.g.
+// "0,7,12") relative to a base note into the set of absolute MIDI notes to
+// sound together for each dot/dash. An empty spec yields just the base note.
+func parseChord(spec string, base int) ([]int, error) {
+	if strings.TrimSpace(spec) == "" {
+		return []int{base}, nil
+	}
+	var notes []int
+	for _, part := range strings.Split(spec, ",") {
+		interval, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid chord interval %q: %w", part, err)
+		}
+		note, err := validateNote(base + interval)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
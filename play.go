@@ -0,0 +1,145 @@
+//go:build portmidi
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rakyll/portmidi"
+	"github.com/urfave/cli/v3"
+)
+
+// playCommand streams Morse code to a live MIDI output port in real time
+// instead of writing a file, sharing its timing with createMIDI via
+// Schedule and pacing NoteOn/NoteOff events with a monotonic clock.
+var playCommand = &cli.Command{
+	Name:  "play",
+	Usage: "stream Morse code to a MIDI output port in real time",
+	Flags: []cli.Flag{
+		&cli.IntFlag{Name: "wpm", Value: 20, Usage: "character speed in words per minute (PARIS standard)"},
+		&cli.IntFlag{Name: "farnsworth", Value: 0, Usage: "effective speed in words per minute, <= wpm, stretching gaps to slow down overall pace (0 disables)"},
+		&cli.BoolFlag{Name: "strict", Usage: "error on unsupported characters instead of skipping them"},
+		&cli.StringFlag{Name: "note", Value: "E5", Usage: "MIDI note number or name (e.g. E5, C#4) played for each dot/dash"},
+		&cli.IntFlag{Name: "velocity", Value: 100, Usage: "MIDI note velocity (0-127)"},
+		&cli.IntFlag{Name: "channel", Value: 0, Usage: "MIDI channel (0-15)"},
+		&cli.IntFlag{Name: "program", Value: -1, Usage: "General MIDI program to send as a Program Change before playing (-1 for none)"},
+		&cli.StringFlag{Name: "chord", Usage: "comma-separated semitone intervals relative to --note, e.g. 0,7,12"},
+		&cli.BoolFlag{Name: "list-ports", Usage: "list available MIDI output ports and exit"},
+		&cli.StringFlag{Name: "port", Usage: "output port to use, by index or name (default: the system default)"},
+	},
+	Action: func(ctx context.Context, c *cli.Command) error {
+		if err := portmidi.Initialize(); err != nil {
+			return fmt.Errorf("initializing portmidi: %w", err)
+		}
+		defer portmidi.Terminate()
+
+		if c.Bool("list-ports") {
+			return listMIDIPorts()
+		}
+
+		deviceID, err := resolveOutputPort(c.String("port"))
+		if err != nil {
+			return err
+		}
+		stream, err := portmidi.NewOutputStream(deviceID, 1024, 0)
+		if err != nil {
+			return fmt.Errorf("opening MIDI output port: %w", err)
+		}
+		defer stream.Close()
+
+		text := strings.Join(c.Args().Slice(), " ")
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("no text provided, please provide text to convert to Morse code")
+		}
+		morse, err := textToMorse(text, c.Bool("strict"))
+		if err != nil {
+			return err
+		}
+		fmt.Println("Morse code:", morse)
+
+		baseNote, err := parseNote(c.String("note"))
+		if err != nil {
+			return err
+		}
+		chordNotes, err := parseChord(c.String("chord"), baseNote)
+		if err != nil {
+			return err
+		}
+		opts := midiOptions{
+			Notes:    chordNotes,
+			Velocity: c.Int("velocity"),
+			Channel:  c.Int("channel"),
+			Program:  c.Int("program"),
+		}
+		if err := validateMIDIOptions(opts); err != nil {
+			return err
+		}
+		if opts.Program >= 0 {
+			if err := stream.WriteShort(int64(0xC0|byte(opts.Channel)), int64(opts.Program), 0); err != nil {
+				return fmt.Errorf("sending program change: %w", err)
+			}
+		}
+
+		timing, err := newTiming(c.Int("wpm"), c.Int("farnsworth"))
+		if err != nil {
+			return err
+		}
+		return playSchedule(stream, Schedule(morse, opts, timing), timing)
+	},
+}
+
+// playSchedule sends each scheduled event to stream, sleeping against a
+// monotonic clock anchored at the start of playback so that per-event sleep
+// error doesn't accumulate over a long message.
+func playSchedule(stream *portmidi.Stream, events []ScheduleEvent, timing noteTiming) error {
+	start := time.Now()
+	for _, ev := range events {
+		target := start.Add(time.Duration(timing.Seconds(ev.OffsetTicks) * float64(time.Second)))
+		if d := time.Until(target); d > 0 {
+			time.Sleep(d)
+		}
+		if err := stream.WriteShort(int64(ev.Status), int64(ev.Data1), int64(ev.Data2)); err != nil {
+			return fmt.Errorf("sending MIDI event: %w", err)
+		}
+	}
+	return nil
+}
+
+// listMIDIPorts prints the index and name of every MIDI output port
+// portmidi can see, for use with --port.
+func listMIDIPorts() error {
+	for i := 0; i < portmidi.CountDevices(); i++ {
+		info := portmidi.Info(portmidi.DeviceID(i))
+		if info.IsOutputAvailable {
+			fmt.Printf("%d: %s\n", i, info.Name)
+		}
+	}
+	return nil
+}
+
+// resolveOutputPort resolves --port to a device ID. An empty spec picks the
+// system default output device; otherwise spec is tried as an index and
+// then as an exact port name.
+func resolveOutputPort(spec string) (portmidi.DeviceID, error) {
+	if spec == "" {
+		id := portmidi.DefaultOutputDeviceID()
+		if id < 0 {
+			return 0, fmt.Errorf("no default MIDI output device available")
+		}
+		return id, nil
+	}
+	if n, err := strconv.Atoi(spec); err == nil {
+		return portmidi.DeviceID(n), nil
+	}
+	for i := 0; i < portmidi.CountDevices(); i++ {
+		info := portmidi.Info(portmidi.DeviceID(i))
+		if info.IsOutputAvailable && info.Name == spec {
+			return portmidi.DeviceID(i), nil
+		}
+	}
+	return 0, fmt.Errorf("no MIDI output port named %q", spec)
+}
@@ -0,0 +1,23 @@
+//go:build !portmidi
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v3"
+)
+
+// playCommand stands in for the real streaming playback command (play.go)
+// when built without the portmidi build tag, since rakyll/portmidi is a
+// cgo binding against the system libportmidi and shouldn't be a hard
+// dependency for users who only want file output. Build with
+// -tags portmidi (and libportmidi installed) to get live playback.
+var playCommand = &cli.Command{
+	Name:  "play",
+	Usage: "stream Morse code to a MIDI output port in real time (unavailable in this build)",
+	Action: func(ctx context.Context, c *cli.Command) error {
+		return fmt.Errorf("play was built without portmidi support; rebuild with -tags portmidi (requires libportmidi)")
+	},
+}
@@ -0,0 +1,51 @@
+package main
+
+// ScheduleEvent is one MIDI channel message to send during playback,
+// expressed as an absolute offset in ticks from the start of the piece.
+type ScheduleEvent struct {
+	OffsetTicks int
+	Status      byte
+	Data1       byte
+	Data2       byte
+}
+
+// Schedule computes the NoteOn/NoteOff events for morse using opts, in
+// order, as absolute tick offsets from t=0 under timing. It uses the same
+// timing as buildNoteTrack, just expressed as a flat event list instead of
+// file-relative delta-times — the form the play subcommand needs to pace a
+// live MIDI stream instead of writing an MTrk chunk.
+func Schedule(morse string, opts midiOptions, timing noteTiming) []ScheduleEvent {
+	var events []ScheduleEvent
+
+	absTick := 0
+	gap := 0
+
+	addNote := func(duration int) {
+		start := absTick + gap
+		for _, note := range opts.Notes {
+			events = append(events, ScheduleEvent{OffsetTicks: start, Status: 0x90 | byte(opts.Channel), Data1: byte(note), Data2: byte(opts.Velocity)})
+		}
+		end := start + duration
+		for _, note := range opts.Notes {
+			events = append(events, ScheduleEvent{OffsetTicks: end, Status: 0x80 | byte(opts.Channel), Data1: byte(note), Data2: 0})
+		}
+		absTick = end
+	}
+
+	for _, symbol := range morse {
+		switch symbol {
+		case '.':
+			addNote(timing.Dot)
+			gap = timing.Dot
+		case '-':
+			addNote(timing.Dash)
+			gap = timing.Dot
+		case ' ':
+			gap = timing.CharGap
+		case '/':
+			gap = timing.WordGap
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// noteTiming is the tick-resolution Morse timing unit lengths (dot, dash,
+// inter-character gap, inter-word gap) plus the MIDI tempo/resolution pair
+// needed to play them, shared by every renderer (MIDI, WAV, live playback).
+type noteTiming struct {
+	TicksPerBeat               int
+	MicrosecondsPerQuarterNote int
+	Dot                        int
+	Dash                       int
+	CharGap                    int // total gap before the next character within a word
+	WordGap                    int // total gap before the next word
+}
+
+// Seconds converts a duration given in ticks to real time under this timing.
+func (t noteTiming) Seconds(ticks int) float64 {
+	return float64(ticks) / float64(t.TicksPerBeat) * float64(t.MicrosecondsPerQuarterNote) / 1e6
+}
+
+// farnsworthTicksPerBeat is the MIDI resolution used for timing derived
+// from --wpm/--farnsworth, high enough that a PARIS dot at any reasonable
+// speed still rounds to a whole number of ticks.
+const farnsworthTicksPerBeat = 960
+
+// newTiming computes Morse timing for wpm characters sent at farnsworth
+// effective speed, using the PARIS standard (dot length in ms = 1200/wpm)
+// and the ARRL Farnsworth spacing formula: the total extra delay per word,
+// (60*wpm - 37.2*farnsworth) / (farnsworth*wpm) seconds, is distributed as
+// 3 units between characters and 7 units between words. A farnsworth of 0
+// (or >= wpm) disables stretching, giving the plain 4-dot/7-dot spacing.
+func newTiming(wpm int, farnsworth int) (noteTiming, error) {
+	if wpm <= 0 {
+		return noteTiming{}, fmt.Errorf("wpm must be positive, got %d", wpm)
+	}
+	if farnsworth < 0 {
+		return noteTiming{}, fmt.Errorf("farnsworth must be >= 0, got %d", farnsworth)
+	}
+
+	dotMs := 1200.0 / float64(wpm)
+
+	charGapMs := 4 * dotMs
+	wordGapMs := 7 * dotMs
+
+	if farnsworth > 0 && farnsworth < wpm {
+		extraPerWordSeconds := (60*float64(wpm) - 37.2*float64(farnsworth)) / (float64(farnsworth) * float64(wpm))
+		extraPerUnitMs := extraPerWordSeconds * 1000 / 10
+		charGapMs += 3 * extraPerUnitMs
+		wordGapMs += 7 * extraPerUnitMs
+	}
+
+	// A quarter note spans two dots, pinned to a high PPQ so the
+	// (possibly stretched) gaps still round to whole ticks.
+	quarterNoteMs := 2 * dotMs
+	ticksPerMs := float64(farnsworthTicksPerBeat) / quarterNoteMs
+
+	dot := farnsworthTicksPerBeat / 2
+	return noteTiming{
+		TicksPerBeat:               farnsworthTicksPerBeat,
+		MicrosecondsPerQuarterNote: int(math.Round(quarterNoteMs * 1000)),
+		Dot:                        dot,
+		Dash:                       3 * dot,
+		CharGap:                    int(math.Round(charGapMs * ticksPerMs)),
+		WordGap:                    int(math.Round(wordGapMs * ticksPerMs)),
+	}, nil
+}
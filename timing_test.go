@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+func TestNewTimingFarnsworthStretchesGapsOnly(t *testing.T) {
+	plain, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming(20, 0): %v", err)
+	}
+	slow, err := newTiming(20, 5)
+	if err != nil {
+		t.Fatalf("newTiming(20, 5): %v", err)
+	}
+
+	if slow.Dot != plain.Dot || slow.Dash != plain.Dash {
+		t.Errorf("Farnsworth changed dot/dash length: got Dot=%d Dash=%d, want Dot=%d Dash=%d",
+			slow.Dot, slow.Dash, plain.Dot, plain.Dash)
+	}
+	if slow.CharGap <= plain.CharGap {
+		t.Errorf("CharGap = %d, want > plain CharGap %d", slow.CharGap, plain.CharGap)
+	}
+	if slow.WordGap <= plain.WordGap {
+		t.Errorf("WordGap = %d, want > plain WordGap %d", slow.WordGap, plain.WordGap)
+	}
+}
+
+func TestNewTimingFarnsworthAtOrAboveWPMDisablesStretch(t *testing.T) {
+	plain, err := newTiming(20, 0)
+	if err != nil {
+		t.Fatalf("newTiming(20, 0): %v", err)
+	}
+	atWPM, err := newTiming(20, 20)
+	if err != nil {
+		t.Fatalf("newTiming(20, 20): %v", err)
+	}
+
+	if atWPM.CharGap != plain.CharGap || atWPM.WordGap != plain.WordGap {
+		t.Errorf("farnsworth == wpm should not stretch gaps: got CharGap=%d WordGap=%d, want CharGap=%d WordGap=%d",
+			atWPM.CharGap, atWPM.WordGap, plain.CharGap, plain.WordGap)
+	}
+}
+
+func TestNewTimingRejectsNonPositiveWPM(t *testing.T) {
+	if _, err := newTiming(0, 0); err == nil {
+		t.Error("newTiming(0, 0) = nil error, want an error")
+	}
+	if _, err := newTiming(-5, 0); err == nil {
+		t.Error("newTiming(-5, 0) = nil error, want an error")
+	}
+}
+
+func TestNewTimingRejectsNegativeFarnsworth(t *testing.T) {
+	if _, err := newTiming(20, -1); err == nil {
+		t.Error("newTiming(20, -1) = nil error, want an error")
+	}
+}
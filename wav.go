@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+)
+
+const (
+	wavSampleRate    = 44100
+	wavBitsPerSample = 16
+	wavChannels      = 1
+	wavToneFrequency = 600.0 // Hz, a typical CW sidetone pitch
+	wavAmplitude     = 0.8   // fraction of full scale, leaves some headroom
+)
+
+// createWAV renders morse to a mono 16-bit PCM WAV file: a sine tone at
+// wavToneFrequency for each dot/dash and silence for the gaps between them,
+// computed from the same timing as createMIDI.
+func createWAV(morse string, filename string, timing noteTiming) error {
+	dot := timing.Seconds(timing.Dot)
+	dash := timing.Seconds(timing.Dash)
+	charGap := timing.Seconds(timing.CharGap)
+	wordGap := timing.Seconds(timing.WordGap)
+
+	var samples []byte
+	appendTone := func(seconds float64) {
+		n := int(seconds * wavSampleRate)
+		for i := 0; i < n; i++ {
+			t := float64(i) / wavSampleRate
+			v := int16(math.Sin(2*math.Pi*wavToneFrequency*t) * wavAmplitude * math.MaxInt16)
+			sample := make([]byte, 2)
+			binary.LittleEndian.PutUint16(sample, uint16(v))
+			samples = append(samples, sample...)
+		}
+	}
+	appendSilence := func(seconds float64) {
+		if seconds <= 0 {
+			return
+		}
+		n := int(seconds * wavSampleRate)
+		samples = append(samples, make([]byte, n*2)...)
+	}
+
+	for _, symbol := range morse {
+		switch symbol {
+		case '.':
+			appendTone(dot)
+			appendSilence(dot)
+		case '-':
+			appendTone(dash)
+			appendSilence(dot)
+		case ' ':
+			appendSilence(charGap - dot)
+		case '/':
+			appendSilence(wordGap - dot)
+		}
+	}
+
+	return os.WriteFile(filename, wavFile(samples), 0644)
+}
+
+// wavFile wraps raw mono 16-bit little-endian PCM samples in a RIFF/WAVE
+// header (riff/wave/fmt/data chunks).
+func wavFile(samples []byte) []byte {
+	byteRate := wavSampleRate * wavChannels * wavBitsPerSample / 8
+	blockAlign := wavChannels * wavBitsPerSample / 8
+
+	header := []byte{'R', 'I', 'F', 'F'}
+	riffSize := make([]byte, 4)
+	binary.LittleEndian.PutUint32(riffSize, uint32(36+len(samples)))
+	header = append(header, riffSize...)
+	header = append(header, 'W', 'A', 'V', 'E')
+
+	header = append(header, 'f', 'm', 't', ' ')
+	fmtSize := make([]byte, 4)
+	binary.LittleEndian.PutUint32(fmtSize, 16)
+	header = append(header, fmtSize...)
+
+	fmtChunk := make([]byte, 16)
+	binary.LittleEndian.PutUint16(fmtChunk[0:2], 1) // PCM
+	binary.LittleEndian.PutUint16(fmtChunk[2:4], wavChannels)
+	binary.LittleEndian.PutUint32(fmtChunk[4:8], wavSampleRate)
+	binary.LittleEndian.PutUint32(fmtChunk[8:12], uint32(byteRate))
+	binary.LittleEndian.PutUint16(fmtChunk[12:14], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(fmtChunk[14:16], wavBitsPerSample)
+	header = append(header, fmtChunk...)
+
+	header = append(header, 'd', 'a', 't', 'a')
+	dataSize := make([]byte, 4)
+	binary.LittleEndian.PutUint32(dataSize, uint32(len(samples)))
+	header = append(header, dataSize...)
+
+	return append(header, samples...)
+}